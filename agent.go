@@ -0,0 +1,54 @@
+package ice
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pion/logging"
+)
+
+// Agent is the ICE agent. This file declares the fields and constructor
+// introduced for candidate liveness monitoring, bounded gathering,
+// pluggable interface discovery, and tiered connection priority; the rest
+// of the Agent implementation (candidate gathering, checklists,
+// connectivity checks, the ICE state machine) lives alongside it.
+type Agent struct {
+	log    logging.LeveledLogger
+	buffer interface {
+		Write([]byte) (int, error)
+	}
+
+	reconnectCh                    chan CandidateReconnectEvent
+	reconnectMaxElapsedTime        time.Duration
+	candidateGatheringTimeout      time.Duration
+	candidateLivenessCheckInterval time.Duration
+	candidateStaleTimeout          time.Duration
+	externalIFaceDiscover          ExternalIFaceDiscover
+
+	selectedPairPriority ConnPriority
+	onPriorityChange     func(ConnPriority)
+
+	inFlightReconnectsMu sync.Mutex
+	inFlightReconnects   map[string]struct{}
+}
+
+// NewAgent creates a new Agent, copying the options relevant to liveness
+// monitoring, bounded gathering, interface discovery, and tiered priority
+// from config onto the Agent's own fields.
+func NewAgent(config *AgentConfig) (*Agent, error) {
+	a := &Agent{
+		reconnectCh:        make(chan CandidateReconnectEvent),
+		inFlightReconnects: make(map[string]struct{}),
+	}
+
+	if config != nil {
+		a.externalIFaceDiscover = config.ExternalIFaceDiscover
+		a.candidateGatheringTimeout = config.CandidateGatheringTimeout
+		a.candidateLivenessCheckInterval = config.CandidateLivenessCheckInterval
+		a.candidateStaleTimeout = config.CandidateStaleTimeout
+		a.reconnectMaxElapsedTime = config.ReconnectMaxElapsedTime
+		a.onPriorityChange = config.OnPriorityChange
+	}
+
+	return a, nil
+}
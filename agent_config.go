@@ -0,0 +1,45 @@
+package ice
+
+import "time"
+
+// AgentConfig fields below are copied onto the Agent's own fields of the
+// same name by NewAgent, and configure candidate liveness monitoring,
+// bounded gathering, pluggable interface discovery, and tiered connection
+// priority.
+type AgentConfig struct {
+	// ExternalIFaceDiscover, when set, is consulted instead of
+	// net.Interfaces() when a candidate starts, to resolve the name of the
+	// interface its local address belongs to (IFaceName), so environments
+	// where the Go runtime cannot enumerate interfaces natively (mobile,
+	// sandboxed containers) can supply their own interface list. It does
+	// not choose which interfaces candidates are gathered from.
+	ExternalIFaceDiscover ExternalIFaceDiscover
+
+	// CandidateGatheringTimeout bounds how long a candidate's recvLoop
+	// waits for its connection to finish initializing before the Agent
+	// proceeds to connectivity checks without it, marking the candidate
+	// GatheringStatePartial. Defaults to
+	// defaultCandidateGatheringTimeout.
+	CandidateGatheringTimeout time.Duration
+
+	// CandidateLivenessCheckInterval is how often a candidate's
+	// LastReceived/LastSent timestamps are sampled for staleness.
+	// Defaults to defaultLivenessCheckInterval.
+	CandidateLivenessCheckInterval time.Duration
+
+	// CandidateStaleTimeout is how long a candidate may go without
+	// inbound or outbound traffic before it is reported on
+	// Agent.ReconnectCh. Defaults to defaultCandidateStaleTimeout.
+	CandidateStaleTimeout time.Duration
+
+	// ReconnectMaxElapsedTime bounds how long the Agent retries a bounded
+	// re-gather cycle after a reconnect event, with exponential backoff
+	// between attempts, before giving up. Defaults to
+	// defaultReconnectMaxElapsedTime.
+	ReconnectMaxElapsedTime time.Duration
+
+	// OnPriorityChange, when set, is called whenever the ConnPriority of
+	// the Agent's selected pair changes, e.g. when the Agent promotes
+	// from a relay pair to a P2P pair.
+	OnPriorityChange func(ConnPriority)
+}
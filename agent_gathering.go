@@ -0,0 +1,13 @@
+package ice
+
+// promoteLateCandidate is called when a candidate that missed
+// CandidateGatheringTimeout finishes initializing afterwards. If ICE has
+// already completed there's nothing left to promote it into; otherwise it's
+// added to the checklist so connectivity checks can use it like any other
+// candidate gathered in time.
+func (a *Agent) promoteLateCandidate(c Candidate) {
+	if a.iceCompleted() {
+		return
+	}
+	a.addChecklistCandidate(c)
+}
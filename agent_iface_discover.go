@@ -0,0 +1,10 @@
+package ice
+
+// ifaceDiscover returns the ExternalIFaceDiscover configured via
+// AgentConfig, falling back to the stdlib-backed default when none is set.
+func (a *Agent) ifaceDiscover() ExternalIFaceDiscover {
+	if a.externalIFaceDiscover != nil {
+		return a.externalIFaceDiscover
+	}
+	return defaultIFaceDiscover{}
+}
@@ -0,0 +1,44 @@
+package ice
+
+// SelectedPairPriority returns the ConnPriority tier of the Agent's
+// currently selected pair.
+func (a *Agent) SelectedPairPriority() ConnPriority {
+	return a.selectedPairPriority
+}
+
+// setSelectedPairPriority updates the selected pair's tier and, if it
+// changed, invokes OnPriorityChange.
+func (a *Agent) setSelectedPairPriority(p ConnPriority) {
+	if a.selectedPairPriority == p {
+		return
+	}
+	a.selectedPairPriority = p
+	if a.onPriorityChange != nil {
+		a.onPriorityChange(p)
+	}
+}
+
+// holdOnRelay is called when the selected pair degrades, whether because
+// the liveness monitor reported staleness or an explicit iCEDisconnected
+// signal fired. It demotes SelectedPairPriority to ConnPriorityRelay and
+// notifies OnPriorityChange; it does not change which pair carries traffic
+// and does not start any connectivity checks. promoteToP2P raises the tier
+// again.
+func (a *Agent) holdOnRelay() {
+	a.setSelectedPairPriority(ConnPriorityRelay)
+}
+
+// promoteToP2P is called once a connectivity check against a
+// ConnPriorityP2P pair succeeds while the Agent is currently holding on a
+// relay pair, swapping the selected pair back to the higher tier.
+func (a *Agent) promoteToP2P() {
+	a.setSelectedPairPriority(ConnPriorityP2P)
+}
+
+// onICEDisconnected is called when the Agent's ICE connection state
+// transitions to Disconnected. Like a liveness staleness event, this demotes
+// SelectedPairPriority to ConnPriorityRelay via holdOnRelay instead of
+// tearing the whole session down.
+func (a *Agent) onICEDisconnected() {
+	a.holdOnRelay()
+}
@@ -0,0 +1,100 @@
+package ice
+
+import "time"
+
+const (
+	// defaultReconnectMaxElapsedTime bounds how long the Agent retries a
+	// bounded re-gather cycle after a reconnect event before giving up,
+	// used when AgentConfig.ReconnectMaxElapsedTime is unset.
+	defaultReconnectMaxElapsedTime = 30 * time.Minute
+
+	// maxReconnectBackoff caps the exponential backoff between re-gather
+	// attempts.
+	maxReconnectBackoff = 30 * time.Second
+)
+
+// ReconnectCh returns the channel on which CandidateReconnectEvent values
+// are delivered when a candidate's liveness monitor detects it has gone
+// silent past its staleness timeout.
+func (a *Agent) ReconnectCh() <-chan CandidateReconnectEvent {
+	return a.reconnectCh
+}
+
+// signalReconnect delivers evt on ReconnectCh, demotes SelectedPairPriority
+// to ConnPriorityRelay via holdOnRelay, and starts a
+// bounded re-gather cycle for the affected candidate rather than tearing
+// down the whole session. If a re-gather is already in flight for this
+// candidate (the ongoing outage that produced evt), it's a no-op: checkLiveness
+// only calls this once per stale transition, but the in-flight guard keeps
+// signalReconnect safe to call more than once for the same outage too.
+func (a *Agent) signalReconnect(evt CandidateReconnectEvent) {
+	if !a.startReconnect(evt.CandidateID) {
+		return
+	}
+
+	a.holdOnRelay()
+
+	select {
+	case a.reconnectCh <- evt:
+	default:
+		// Don't block the liveness monitor if nobody is receiving on
+		// ReconnectCh; the event is dropped, and checkLiveness reports
+		// this candidate again only on its next transition into staleness.
+	}
+
+	go func() {
+		defer a.finishReconnect(evt.CandidateID)
+		if a.reGatherWithBackoff(evt) {
+			a.promoteToP2P()
+		}
+	}()
+}
+
+// startReconnect records that a re-gather cycle is now in flight for
+// candidateID, returning false if one was already running.
+func (a *Agent) startReconnect(candidateID string) bool {
+	a.inFlightReconnectsMu.Lock()
+	defer a.inFlightReconnectsMu.Unlock()
+
+	if _, inFlight := a.inFlightReconnects[candidateID]; inFlight {
+		return false
+	}
+	a.inFlightReconnects[candidateID] = struct{}{}
+	return true
+}
+
+// finishReconnect clears the in-flight marker set by startReconnect.
+func (a *Agent) finishReconnect(candidateID string) {
+	a.inFlightReconnectsMu.Lock()
+	defer a.inFlightReconnectsMu.Unlock()
+	delete(a.inFlightReconnects, candidateID)
+}
+
+// reGatherWithBackoff retries a bounded re-gather cycle for the candidate
+// named in evt, backing off exponentially between attempts, until it
+// succeeds (returning true) or ReconnectMaxElapsedTime elapses (returning
+// false).
+func (a *Agent) reGatherWithBackoff(evt CandidateReconnectEvent) bool {
+	maxElapsed := a.reconnectMaxElapsedTime
+	if maxElapsed <= 0 {
+		maxElapsed = defaultReconnectMaxElapsedTime
+	}
+
+	deadline := time.Now().Add(maxElapsed)
+	backoff := time.Second
+
+	for time.Now().Before(deadline) {
+		if err := a.reGatherCandidate(evt.CandidateID); err == nil {
+			return true
+		}
+
+		time.Sleep(backoff)
+		if backoff < maxReconnectBackoff {
+			backoff *= 2
+			if backoff > maxReconnectBackoff {
+				backoff = maxReconnectBackoff
+			}
+		}
+	}
+	return false
+}
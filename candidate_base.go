@@ -22,6 +22,19 @@ type candidateBase struct {
 	relatedAddress *CandidateRelatedAddress
 	tcpType        TCPType
 
+	// ifaceName is the network interface this candidate was gathered
+	// from, as reported by the configured ExternalIFaceDiscover (or
+	// net.Interfaces() when none is configured). Used for later filtering
+	// and logging of inbound traffic.
+	ifaceName string
+
+	// viaTURN is true when this candidate was constructed from a TURN
+	// allocation response, regardless of its CandidateType. It is the
+	// source of truth ConnPriority uses to classify a candidate as
+	// ConnPriorityRelay, since RelatedAddress can't distinguish a
+	// TURN-derived server-reflexive candidate from a plain STUN one.
+	viaTURN bool
+
 	resolvedAddr net.Addr
 
 	lastSent     atomic.Value
@@ -31,6 +44,27 @@ type candidateBase struct {
 	currAgent *Agent
 	closeCh   chan struct{}
 	closedCh  chan struct{}
+
+	// livenessCheckInterval and staleTimeout configure the background
+	// liveness monitor started alongside recvLoop. Zero means "use the
+	// Agent's configured value, or the package default if that is unset".
+	livenessCheckInterval time.Duration
+	staleTimeout          time.Duration
+	generation            uint32
+
+	// wasStale records whether the last checkLiveness tick found the
+	// candidate stale, so generation only advances on the transition into
+	// staleness. Only livenessMonitor's goroutine touches it.
+	wasStale bool
+
+	// gatheringState is a GatheringState set once recvLoop either sees
+	// initializedCh fire or times out waiting for it.
+	gatheringState int32
+
+	// gatheringTimeout overrides defaultCandidateGatheringTimeout /
+	// AgentConfig.CandidateGatheringTimeout for this candidate. Zero
+	// means "use the Agent's configured value".
+	gatheringTimeout time.Duration
 }
 
 // Done implements context.Context
@@ -102,8 +136,23 @@ func (c *candidateBase) TCPType() TCPType {
 	return c.tcpType
 }
 
-// start runs the candidate using the provided connection
-func (c *candidateBase) start(a *Agent, conn net.PacketConn, initializedCh <-chan struct{}) {
+// IFaceName returns the name of the network interface this candidate was
+// gathered from, if known.
+func (c *candidateBase) IFaceName() string {
+	return c.ifaceName
+}
+
+// setViaTURN marks this candidate as having been constructed from a TURN
+// allocation response, which ConnPriority uses to classify it as
+// ConnPriorityRelay.
+func (c *candidateBase) setViaTURN(viaTURN bool) {
+	c.viaTURN = viaTURN
+}
+
+// start runs the candidate using the provided connection. viaTURN marks
+// whether this candidate (srflx or relay) was constructed from a TURN
+// allocation response, which ConnPriority uses to classify it.
+func (c *candidateBase) start(a *Agent, conn net.PacketConn, initializedCh <-chan struct{}, viaTURN bool) {
 	if c.conn != nil {
 		c.agent().log.Warn("Can't start already started candidateBase")
 		return
@@ -112,21 +161,43 @@ func (c *candidateBase) start(a *Agent, conn net.PacketConn, initializedCh <-cha
 	c.conn = conn
 	c.closeCh = make(chan struct{})
 	c.closedCh = make(chan struct{})
+	c.setViaTURN(viaTURN)
+
+	if c.ifaceName == "" {
+		// For host candidates, the candidate's own address is a local
+		// address and can be matched directly against an interface. For
+		// srflx/relay candidates, Address() is instead the public
+		// address reported by the STUN/TURN server, so the originating
+		// interface has to be resolved from the local base address in
+		// RelatedAddress instead.
+		lookupAddr := c.address
+		if c.relatedAddress != nil && c.relatedAddress.Address != "" {
+			lookupAddr = c.relatedAddress.Address
+		}
+		c.ifaceName = resolveIFaceName(a.ifaceDiscover(), lookupAddr)
+	}
+
+	if c.livenessCheckInterval == 0 {
+		c.livenessCheckInterval = defaultLivenessCheckInterval
+		if a.candidateLivenessCheckInterval > 0 {
+			c.livenessCheckInterval = a.candidateLivenessCheckInterval
+		}
+	}
+	if c.staleTimeout == 0 {
+		c.staleTimeout = defaultCandidateStaleTimeout
+		if a.candidateStaleTimeout > 0 {
+			c.staleTimeout = a.candidateStaleTimeout
+		}
+	}
 
 	go c.recvLoop(initializedCh)
+	go c.livenessMonitor()
 }
 
 func (c *candidateBase) recvLoop(initializedCh <-chan struct{}) {
-	defer func() {
-		close(c.closedCh)
-		c.agent().log.Errorf("glenn recvloop defer called %s", c.id)
-	}()
-	c.agent().log.Errorf("glenn recvloop start %s %s", c.id, c.conn)
-	select {
-	case <-initializedCh:
-		c.agent().log.Errorf("glenn recvloop initializedCh %s %s", c.id, c.conn)
-	case <-c.closeCh:
-		c.agent().log.Errorf("glenn recvloop closeCh %s %s", c.id, c.conn)
+	defer close(c.closedCh)
+
+	if !c.waitInitialized(initializedCh) {
 		return
 	}
 
@@ -135,16 +206,82 @@ func (c *candidateBase) recvLoop(initializedCh <-chan struct{}) {
 	for {
 		n, srcAddr, err := c.conn.ReadFrom(buffer)
 		if err != nil {
-			c.agent().log.Errorf("glenn recvloop connection closed %s %s", c.id, c.conn)
 			return
 		}
 		handleInboundCandidateMsg(c, c, buffer[:n], srcAddr, log)
 	}
 }
 
+// waitInitialized blocks until initializedCh fires, the candidate is
+// closed, or CandidateGatheringTimeout elapses. On timeout it marks the
+// candidate GatheringStatePartial and keeps waiting for initializedCh in
+// the background, so a late-arriving candidate can still be promoted into
+// the checklist if it completes before ICE succeeds. It returns false only
+// once the candidate has been closed.
+func (c *candidateBase) waitInitialized(initializedCh <-chan struct{}) bool {
+	timeout := c.gatheringTimeout
+	if timeout <= 0 {
+		timeout = defaultCandidateGatheringTimeout
+		if a := c.agent(); a != nil && a.candidateGatheringTimeout > 0 {
+			timeout = a.candidateGatheringTimeout
+		}
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-initializedCh:
+		c.setGatheringState(GatheringStateComplete)
+		return true
+	case <-c.closeCh:
+		return false
+	case <-timer.C:
+	}
+
+	// The timeout elapsed before this candidate finished initializing.
+	// Mark it partial and let recvLoop proceed immediately so the Agent
+	// can move on to connectivity checks; keep waiting for initializedCh
+	// in the background so a late-arriving candidate is still marked
+	// complete, and promoted into the checklist if ICE has not completed.
+	c.setGatheringState(GatheringStatePartial)
+	go func() {
+		select {
+		case <-initializedCh:
+			c.setGatheringState(GatheringStateComplete)
+			if a := c.agent(); a != nil {
+				a.promoteLateCandidate(c)
+			}
+		case <-c.closeCh:
+		}
+	}()
+	return true
+}
+
+// GatheringState returns GatheringStateComplete once this candidate has
+// finished initializing, whether before CandidateGatheringTimeout elapsed or
+// late, and GatheringStatePartial while it is still outstanding after the
+// timeout. Before recvLoop has seen either, it returns the zero value,
+// GatheringStateComplete.
+func (c *candidateBase) GatheringState() GatheringState {
+	return GatheringState(atomic.LoadInt32(&c.gatheringState))
+}
+
+func (c *candidateBase) setGatheringState(s GatheringState) {
+	atomic.StoreInt32(&c.gatheringState, int32(s))
+}
+
+// candidateIfaceName returns the IFaceName() of c when it's a
+// *candidateBase (the only implementation that tracks one), or "" for any
+// other Candidate.
+func candidateIfaceName(c Candidate) string {
+	if cb, ok := c.(*candidateBase); ok {
+		return cb.IFaceName()
+	}
+	return ""
+}
+
 func handleInboundCandidateMsg(ctx context.Context, c Candidate, buffer []byte, srcAddr net.Addr, log logging.LeveledLogger) {
-	defer c.agent().log.Errorf("glenn handleInboundCandidateMsg end %s", c.ID())
-	c.agent().log.Errorf("glenn handleInboundCandidateMsg start %s", c.ID())
 	if stun.IsMessage(buffer) {
 		m := &stun.Message{
 			Raw: make([]byte, len(buffer)),
@@ -166,7 +303,7 @@ func handleInboundCandidateMsg(ctx context.Context, c Candidate, buffer []byte,
 	}
 
 	if !c.agent().validateNonSTUNTraffic(c, srcAddr) {
-		log.Warnf("Discarded message from %s, not a valid remote candidate", c.addr())
+		log.Warnf("Discarded message from %s (iface %s), not a valid remote candidate", c.addr(), candidateIfaceName(c))
 		return
 	}
 
@@ -199,7 +336,6 @@ func (c *candidateBase) close() error {
 	}
 
 	// Close the conn
-	c.agent().log.Errorf("glenn c.conn.Close() %s, %s", c.id, c.conn)
 	if err := c.conn.Close(); err != nil && firstErr == nil {
 		firstErr = err
 	}
@@ -209,9 +345,7 @@ func (c *candidateBase) close() error {
 	}
 
 	// Wait until the recvLoop is closed
-	c.agent().log.Errorf("glenn closedCh start %s, %s", c.id, c.conn)
 	<-c.closedCh
-	c.agent().log.Errorf("glenn closedCh end %s", c.id)
 	return nil
 }
 
@@ -0,0 +1,51 @@
+package ice
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCandidateBaseWaitInitializedImmediate(t *testing.T) {
+	c := &candidateBase{closeCh: make(chan struct{}), gatheringTimeout: time.Hour}
+	initializedCh := make(chan struct{})
+	close(initializedCh)
+
+	if !c.waitInitialized(initializedCh) {
+		t.Fatal("waitInitialized returned false, want true")
+	}
+	if got := c.GatheringState(); got != GatheringStateComplete {
+		t.Fatalf("GatheringState() = %v, want %v", got, GatheringStateComplete)
+	}
+}
+
+func TestCandidateBaseWaitInitializedClosed(t *testing.T) {
+	c := &candidateBase{closeCh: make(chan struct{}), gatheringTimeout: time.Hour}
+	close(c.closeCh)
+
+	if c.waitInitialized(make(chan struct{})) {
+		t.Fatal("waitInitialized returned true, want false")
+	}
+}
+
+func TestCandidateBaseWaitInitializedTimeoutThenLateArrival(t *testing.T) {
+	c := &candidateBase{closeCh: make(chan struct{}), gatheringTimeout: 10 * time.Millisecond}
+	initializedCh := make(chan struct{})
+
+	if !c.waitInitialized(initializedCh) {
+		t.Fatal("waitInitialized returned false, want true")
+	}
+	if got := c.GatheringState(); got != GatheringStatePartial {
+		t.Fatalf("GatheringState() = %v, want %v", got, GatheringStatePartial)
+	}
+
+	close(initializedCh)
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if c.GatheringState() == GatheringStateComplete {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("GatheringState() never became %v after late initializedCh", GatheringStateComplete)
+}
@@ -0,0 +1,90 @@
+package ice
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// defaultLivenessCheckInterval is how often a candidate's LastReceived
+	// and LastSent timestamps are sampled for staleness, used when the
+	// Agent does not override it via AgentConfig.
+	defaultLivenessCheckInterval = 5 * time.Minute
+
+	// defaultCandidateStaleTimeout is how long a candidate may go without
+	// inbound or outbound traffic before it is reported on
+	// Agent.ReconnectCh.
+	defaultCandidateStaleTimeout = 15 * time.Second
+)
+
+// CandidateReconnectEvent is sent on Agent.ReconnectCh when a candidate has
+// gone silent past its staleness timeout. Generation increases monotonically
+// per candidate so callers can dedupe events raised for the same liveness
+// loss.
+type CandidateReconnectEvent struct {
+	CandidateID string
+	Component   uint16
+	NetworkType NetworkType
+	Outbound    bool
+	Generation  uint32
+}
+
+// livenessMonitor samples LastReceived/LastSent on livenessCheckInterval and
+// reports a CandidateReconnectEvent once the candidate has been silent past
+// staleTimeout. It runs for the lifetime of the candidate alongside recvLoop
+// and exits when closeCh is closed.
+func (c *candidateBase) livenessMonitor() {
+	ticker := time.NewTicker(c.livenessCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.closeCh:
+			return
+		case <-ticker.C:
+			c.checkLiveness()
+		}
+	}
+}
+
+// checkLiveness reports a reconnect event on the transition into staleness.
+// It tracks LastReceived and LastSent independently: either direction
+// crossing staleTimeout on its own counts as stale, even if the other
+// direction is still fresh (e.g. we keep sending keepalives while the
+// remote has gone silent). Generation only advances on that transition, not
+// on every tick the candidate remains stale, so it stays meaningful for
+// dedup; livenessMonitor is the only goroutine that touches wasStale, so it
+// needs no synchronization of its own.
+func (c *candidateBase) checkLiveness() {
+	stale, outbound := evaluateLiveness(c.LastReceived(), c.LastSent(), time.Now(), c.staleTimeout)
+	if !stale {
+		c.wasStale = false
+		return
+	}
+	if c.wasStale {
+		return
+	}
+	c.wasStale = true
+
+	gen := atomic.AddUint32(&c.generation, 1)
+	c.agent().signalReconnect(CandidateReconnectEvent{
+		CandidateID: c.id,
+		Component:   c.component,
+		NetworkType: c.networkType,
+		Outbound:    outbound,
+		Generation:  gen,
+	})
+}
+
+// evaluateLiveness reports whether a candidate last seen receiving at
+// lastReceived and sending at lastSent should be considered stale as of now,
+// and if so whether the loss is on the outbound path (we've stopped
+// sending) as opposed to inbound (the remote has gone silent). A direction
+// that has never seen traffic (zero time) is never considered stale on its
+// own, so a freshly started candidate doesn't immediately fire. It has no
+// Agent dependency so it can be tested directly.
+func evaluateLiveness(lastReceived, lastSent, now time.Time, staleTimeout time.Duration) (stale, outbound bool) {
+	receivedStale := !lastReceived.IsZero() && now.Sub(lastReceived) >= staleTimeout
+	sentStale := !lastSent.IsZero() && now.Sub(lastSent) >= staleTimeout
+	return receivedStale || sentStale, sentStale
+}
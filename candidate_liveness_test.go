@@ -0,0 +1,132 @@
+package ice
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckLivenessGenerationOnlyAdvancesOnTransition(t *testing.T) {
+	a, err := NewAgent(nil)
+	if err != nil {
+		t.Fatalf("NewAgent: %v", err)
+	}
+
+	staleTimeout := 10 * time.Millisecond
+	c := &candidateBase{
+		id:           "cand1",
+		currAgent:    a,
+		staleTimeout: staleTimeout,
+	}
+	c.setLastReceived(time.Now())
+	c.setLastSent(time.Now())
+
+	// Still fresh: no event, no generation bump.
+	c.checkLiveness()
+	if c.generation != 0 {
+		t.Fatalf("generation = %d, want 0 while fresh", c.generation)
+	}
+
+	time.Sleep(2 * staleTimeout)
+
+	// First tick past staleTimeout: the transition into staleness, bumps once.
+	c.checkLiveness()
+	if c.generation != 1 {
+		t.Fatalf("generation = %d, want 1 on stale transition", c.generation)
+	}
+
+	// Still stale on the next tick: must not bump again.
+	c.checkLiveness()
+	if c.generation != 1 {
+		t.Fatalf("generation = %d, want still 1 while stale persists", c.generation)
+	}
+
+	// Recover, then go stale again: must bump on the new transition.
+	c.setLastReceived(time.Now())
+	c.setLastSent(time.Now())
+	c.checkLiveness()
+	if c.generation != 1 {
+		t.Fatalf("generation = %d, want still 1 once recovered", c.generation)
+	}
+
+	time.Sleep(2 * staleTimeout)
+	c.checkLiveness()
+	if c.generation != 2 {
+		t.Fatalf("generation = %d, want 2 on the second stale transition", c.generation)
+	}
+}
+
+func TestStartReconnectDedupsInFlightRetries(t *testing.T) {
+	a, err := NewAgent(nil)
+	if err != nil {
+		t.Fatalf("NewAgent: %v", err)
+	}
+
+	if !a.startReconnect("cand1") {
+		t.Fatalf("startReconnect should report true on first call for a candidate")
+	}
+	if a.startReconnect("cand1") {
+		t.Fatalf("startReconnect should report false while a retry is already in flight")
+	}
+
+	a.finishReconnect("cand1")
+	if !a.startReconnect("cand1") {
+		t.Fatalf("startReconnect should report true again once the prior retry finished")
+	}
+}
+
+func TestEvaluateLiveness(t *testing.T) {
+	const staleTimeout = 10 * time.Second
+	now := time.Now()
+
+	cases := []struct {
+		name         string
+		lastReceived time.Time
+		lastSent     time.Time
+		wantStale    bool
+		wantOutbound bool
+	}{
+		{
+			name:         "both fresh",
+			lastReceived: now.Add(-time.Second),
+			lastSent:     now.Add(-time.Second),
+			wantStale:    false,
+		},
+		{
+			name:         "inbound stale, outbound fresh",
+			lastReceived: now.Add(-time.Minute),
+			lastSent:     now.Add(-time.Second),
+			wantStale:    true,
+			wantOutbound: false,
+		},
+		{
+			name:         "outbound stale, inbound fresh",
+			lastReceived: now.Add(-time.Second),
+			lastSent:     now.Add(-time.Minute),
+			wantStale:    true,
+			wantOutbound: true,
+		},
+		{
+			name:         "both stale",
+			lastReceived: now.Add(-time.Minute),
+			lastSent:     now.Add(-time.Minute),
+			wantStale:    true,
+			wantOutbound: true,
+		},
+		{
+			name:      "never seen either direction",
+			wantStale: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stale, outbound := evaluateLiveness(c.lastReceived, c.lastSent, now, staleTimeout)
+			if stale != c.wantStale {
+				t.Fatalf("stale = %v, want %v", stale, c.wantStale)
+			}
+			if stale && outbound != c.wantOutbound {
+				t.Fatalf("outbound = %v, want %v", outbound, c.wantOutbound)
+			}
+		})
+	}
+}
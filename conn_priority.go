@@ -0,0 +1,47 @@
+package ice
+
+// ConnPriority is a coarse-grained tier layered on top of the RFC 5245
+// Priority() calculation. It records whether the Agent's selected pair is on
+// the lower tier (relay) or the higher tier (P2P); see SelectedPairPriority
+// and OnPriorityChange.
+type ConnPriority int
+
+const (
+	// ConnPriorityRelay is the fallback tier: relay candidates, and any
+	// server-reflexive/relay pair derived from a TURN allocation.
+	ConnPriorityRelay ConnPriority = iota + 1
+
+	// ConnPriorityP2P is the preferred tier: true peer-to-peer pairs
+	// (host, peer-reflexive, and server-reflexive candidates not backed
+	// by a TURN allocation).
+	ConnPriorityP2P
+)
+
+// String implements fmt.Stringer.
+func (p ConnPriority) String() string {
+	switch p {
+	case ConnPriorityRelay:
+		return "relay"
+	case ConnPriorityP2P:
+		return "p2p"
+	default:
+		return "unknown"
+	}
+}
+
+// ConnPriority returns the coarse-grained tier for this candidate: relay
+// candidates, and any candidate resolved via a TURN allocation (including a
+// TURN-derived server-reflexive candidate), are ConnPriorityRelay;
+// everything else (true P2P candidates) is ConnPriorityP2P.
+//
+// Note this deliberately does not use RelatedAddress() as a TURN signal: a
+// server-reflexive candidate's related address is its local base address
+// whether it was discovered via plain STUN or a TURN allocation, so it
+// can't distinguish the two. viaTURN is set explicitly when the candidate
+// is constructed from a TURN allocation response.
+func (c *candidateBase) ConnPriority() ConnPriority {
+	if c.candidateType == CandidateTypeRelay || c.viaTURN {
+		return ConnPriorityRelay
+	}
+	return ConnPriorityP2P
+}
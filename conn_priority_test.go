@@ -0,0 +1,68 @@
+package ice
+
+import "testing"
+
+func TestConnPriority(t *testing.T) {
+	cases := []struct {
+		name    string
+		ctype   CandidateType
+		viaTURN bool
+		want    ConnPriority
+	}{
+		{"relay", CandidateTypeRelay, false, ConnPriorityRelay},
+		{"turn-derived srflx", CandidateTypeServerReflexive, true, ConnPriorityRelay},
+		{"plain stun srflx", CandidateTypeServerReflexive, false, ConnPriorityP2P},
+		{"host", CandidateTypeHost, false, ConnPriorityP2P},
+		{"peer reflexive", CandidateTypePeerReflexive, false, ConnPriorityP2P},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cb := &candidateBase{candidateType: c.ctype, viaTURN: c.viaTURN}
+			if got := cb.ConnPriority(); got != c.want {
+				t.Fatalf("ConnPriority() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestSelectedPairPriorityHoldAndPromote(t *testing.T) {
+	var changes []ConnPriority
+	a, err := NewAgent(&AgentConfig{
+		OnPriorityChange: func(p ConnPriority) { changes = append(changes, p) },
+	})
+	if err != nil {
+		t.Fatalf("NewAgent: %v", err)
+	}
+
+	a.holdOnRelay()
+	a.holdOnRelay() // already relay: must not re-fire the callback
+	a.promoteToP2P()
+
+	want := []ConnPriority{ConnPriorityRelay, ConnPriorityP2P}
+	if len(changes) != len(want) {
+		t.Fatalf("OnPriorityChange fired %v, want %v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Fatalf("changes[%d] = %v, want %v", i, changes[i], want[i])
+		}
+	}
+
+	if got := a.SelectedPairPriority(); got != ConnPriorityP2P {
+		t.Fatalf("SelectedPairPriority() = %v, want %v", got, ConnPriorityP2P)
+	}
+}
+
+func TestOnICEDisconnectedHoldsOnRelay(t *testing.T) {
+	a, err := NewAgent(nil)
+	if err != nil {
+		t.Fatalf("NewAgent: %v", err)
+	}
+
+	a.onICEDisconnected()
+
+	if got := a.SelectedPairPriority(); got != ConnPriorityRelay {
+		t.Fatalf("SelectedPairPriority() = %v, want %v", got, ConnPriorityRelay)
+	}
+}
@@ -0,0 +1,79 @@
+package ice
+
+import "net"
+
+// ExternalIFaceDiscover lets an application supply its own view of the
+// host's network interfaces and their addresses, for environments where the
+// Go runtime cannot enumerate interfaces natively (e.g. mobile platforms,
+// sandboxed containers). It can be injected via AgentConfig; when set, it is
+// consulted in preference to net.Interfaces() when a candidate starts, to
+// find the name of the interface its local address belongs to (IFaceName).
+// It is not used to choose which interfaces or addresses candidates are
+// gathered from.
+type ExternalIFaceDiscover interface {
+	// IFaceList returns the names of the available network interfaces.
+	IFaceList() ([]string, error)
+
+	// InterfaceAddresses returns the addresses bound to the named
+	// interface.
+	InterfaceAddresses(iface string) ([]net.Addr, error)
+}
+
+// defaultIFaceDiscover is the ExternalIFaceDiscover used when AgentConfig
+// does not supply one. It wraps the stdlib net package.
+type defaultIFaceDiscover struct{}
+
+func (defaultIFaceDiscover) IFaceList() ([]string, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(ifaces))
+	for _, iface := range ifaces {
+		names = append(names, iface.Name)
+	}
+	return names, nil
+}
+
+func (defaultIFaceDiscover) InterfaceAddresses(iface string) ([]net.Addr, error) {
+	i, err := net.InterfaceByName(iface)
+	if err != nil {
+		return nil, err
+	}
+	return i.Addrs()
+}
+
+// resolveIFaceName returns the name of the interface that owns address, as
+// reported by discover (or defaultIFaceDiscover when discover is nil). It
+// returns "" if address isn't a valid IP or no interface could be matched.
+// candidateBase.start calls it to fill in a candidate's IFaceName.
+func resolveIFaceName(discover ExternalIFaceDiscover, address string) string {
+	if discover == nil {
+		discover = defaultIFaceDiscover{}
+	}
+
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return ""
+	}
+
+	names, err := discover.IFaceList()
+	if err != nil {
+		return ""
+	}
+
+	for _, name := range names {
+		addrs, err := discover.InterfaceAddresses(name)
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			ifaceIP, _, err := net.ParseCIDR(addr.String())
+			if err == nil && ifaceIP.Equal(ip) {
+				return name
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+package ice
+
+import (
+	"errors"
+	"net"
+	"testing"
+)
+
+type fakeIFaceDiscover struct {
+	ifaces   []string
+	addrs    map[string][]net.Addr
+	listErr  error
+	addrsErr map[string]error
+}
+
+func (f fakeIFaceDiscover) IFaceList() ([]string, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	return f.ifaces, nil
+}
+
+func (f fakeIFaceDiscover) InterfaceAddresses(iface string) ([]net.Addr, error) {
+	if err, ok := f.addrsErr[iface]; ok {
+		return nil, err
+	}
+	return f.addrs[iface], nil
+}
+
+type stringAddr string
+
+func (a stringAddr) Network() string { return "ip+net" }
+func (a stringAddr) String() string  { return string(a) }
+
+func TestResolveIFaceName(t *testing.T) {
+	discover := fakeIFaceDiscover{
+		ifaces: []string{"eth0", "eth1", "lo"},
+		addrs: map[string][]net.Addr{
+			"eth0": {stringAddr("192.168.1.5/24")},
+			"eth1": {stringAddr("10.0.0.9/8")},
+			"lo":   {stringAddr("127.0.0.1/8")},
+		},
+		addrsErr: map[string]error{
+			"lo": errors.New("boom"),
+		},
+	}
+
+	cases := []struct {
+		name    string
+		address string
+		want    string
+	}{
+		{"matches second interface", "10.0.0.9", "eth1"},
+		{"matches first interface", "192.168.1.5", "eth0"},
+		{"no match", "172.16.0.1", ""},
+		{"invalid address", "not-an-ip", ""},
+		{"interface addrs error is skipped, not fatal", "127.0.0.1", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := resolveIFaceName(discover, c.address); got != c.want {
+				t.Fatalf("resolveIFaceName(%q) = %q, want %q", c.address, got, c.want)
+			}
+		})
+	}
+}
+
+func TestResolveIFaceNameIFaceListError(t *testing.T) {
+	discover := fakeIFaceDiscover{listErr: errors.New("boom")}
+	if got := resolveIFaceName(discover, "192.168.1.5"); got != "" {
+		t.Fatalf("resolveIFaceName() = %q, want empty string on IFaceList error", got)
+	}
+}
+
+func TestResolveIFaceNameNilDiscoverUsesDefault(t *testing.T) {
+	// With a nil discoverer, resolveIFaceName falls back to
+	// defaultIFaceDiscover, which walks the real host interfaces. We can't
+	// assert a specific match, but it must not panic and must return "" for
+	// an address no local interface owns.
+	if got := resolveIFaceName(nil, "203.0.113.1"); got != "" {
+		t.Fatalf("resolveIFaceName(nil, ...) = %q, want empty string for an unowned address", got)
+	}
+}
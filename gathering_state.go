@@ -0,0 +1,37 @@
+package ice
+
+import "time"
+
+// defaultCandidateGatheringTimeout bounds how long recvLoop waits for a
+// candidate's initializedCh before proceeding to connectivity checks
+// without it, used when AgentConfig.CandidateGatheringTimeout is unset.
+const defaultCandidateGatheringTimeout = 5 * time.Second
+
+// GatheringState indicates whether a candidate has finished initializing, or
+// CandidateGatheringTimeout elapsed first and it is still being resolved in
+// the background.
+type GatheringState int
+
+const (
+	// GatheringStateComplete indicates the candidate finished
+	// initializing, either before the gathering timeout elapsed or later,
+	// as a late arrival.
+	GatheringStateComplete GatheringState = iota
+
+	// GatheringStatePartial indicates CandidateGatheringTimeout elapsed
+	// before the candidate finished initializing. It may still be
+	// promoted into the checklist if it completes before ICE succeeds.
+	GatheringStatePartial
+)
+
+// String implements fmt.Stringer.
+func (s GatheringState) String() string {
+	switch s {
+	case GatheringStateComplete:
+		return "complete"
+	case GatheringStatePartial:
+		return "partial"
+	default:
+		return "unknown"
+	}
+}